@@ -13,6 +13,8 @@ import (
 	"path/filepath"
 	"strings"
 
+	cdx "github.com/CycloneDX/cyclonedx-go"
+
 	"github.com/CycloneDX/cyclonedx-gomod/internal/gocmd"
 	"github.com/CycloneDX/cyclonedx-gomod/internal/util"
 	"golang.org/x/mod/sumdb/dirhash"
@@ -61,6 +63,11 @@ type Module struct {
 
 	Dependencies []*Module `json:"-"`
 	Vendored     bool      `json:"-"`
+	Origin       *Origin   `json:"-"`
+
+	// Sum is this module's precomputed h1 hash, as recorded in go.sum.
+	// When set, Hash returns it instead of rehashing Dir.
+	Sum string `json:"-"`
 }
 
 func (m Module) Coordinates() string {
@@ -71,6 +78,10 @@ func (m Module) Coordinates() string {
 }
 
 func (m Module) Hash() (string, error) {
+	if m.Sum != "" {
+		return m.Sum, nil
+	}
+
 	h1, err := dirhash.HashDir(m.Dir, m.Coordinates(), dirhash.Hash1)
 	if err != nil {
 		return "", err
@@ -93,6 +104,28 @@ func (m Module) PackageURL() string {
 	return "pkg:golang/" + m.Coordinates()
 }
 
+// ExternalReferences returns the CycloneDX external references for m's
+// component, including a pointer back at the exact VCS revision its version
+// was resolved from, when known.
+func (m Module) ExternalReferences() []cdx.ExternalReference {
+	var refs []cdx.ExternalReference
+	if ref := m.Origin.ExternalReference(); ref != nil {
+		refs = append(refs, *ref)
+	}
+	return refs
+}
+
+// Properties returns the CycloneDX properties for m's component, capturing
+// metadata that doesn't have a dedicated BOM field, such as the commit time
+// its version was resolved from.
+func (m Module) Properties() []cdx.Property {
+	var props []cdx.Property
+	if prop := m.Origin.Property(); prop != nil {
+		props = append(props, *prop)
+	}
+	return props
+}
+
 func GetModules(path string) ([]Module, error) {
 	if !util.IsGoModule(path) {
 		return nil, ErrNoGoModule
@@ -311,14 +344,16 @@ func resolveLocalModule(mainModulePath string, module *Module) error {
 
 	module.Path = localModule.Path
 
-	// Try to resolve the version. Only works when module.Dir is a Git repo.
+	// Try to resolve the version. Only works when module.Dir is a working copy
+	// of a VCS we support (see the vcs package).
 	if module.Version == "" {
-		version, err := GetModuleVersion(module.Dir)
+		version, origin, err := GetModuleVersion(module.Dir, module.Path)
 		if err == nil {
 			module.Version = version
+			module.Origin = origin
 		} else {
 			// We don't fail with an error here, because our possibilities are limited.
-			// module.Dir may be a Mercurial repo or just a normal directory, in which case we
+			// module.Dir may not be under version control at all, in which case we
 			// cannot detect versions reliably right now.
 			log.Printf("failed to resolve version of local module %s: %v\n", module.Path, err)
 		}
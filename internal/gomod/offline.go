@@ -0,0 +1,235 @@
+package gomod
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+
+	"github.com/CycloneDX/cyclonedx-gomod/internal/util"
+)
+
+// GetModulesOffline reconstructs a module's dependency graph without
+// invoking the go command. It relies solely on the main module's go.mod and
+// go.sum, and on artifacts already present in the local module cache
+// ($GOMODCACHE/cache/download). This makes it possible to produce SBOMs in
+// air-gapped CI environments where reaching GOPROXY or a checksum database
+// isn't an option, complementing the GONOPROXY / GOPRIVATE awareness that
+// PrivateModulePatterns already provides.
+//
+// Like `go list -m`, the returned modules are the ones minimal version
+// selection actually picked, not the raw union of every version ever
+// required - a dependency requiring an older version of a module than the
+// main module's build list selects doesn't show up twice.
+//
+// Note that vendored modules and replace directives pointing at local
+// directories are not supported in offline mode; use GetModules for those.
+func GetModulesOffline(path string) ([]Module, error) {
+	if !util.IsGoModule(path) {
+		return nil, ErrNoGoModule
+	}
+
+	mainGoMod, err := parseGoMod(filepath.Join(path, "go.mod"))
+	if err != nil {
+		return nil, fmt.Errorf("parsing go.mod failed: %w", err)
+	}
+
+	sums, err := readGoSum(filepath.Join(path, "go.sum"))
+	if err != nil {
+		return nil, fmt.Errorf("reading go.sum failed: %w", err)
+	}
+
+	resolver := &offlineResolver{
+		cacheDir: util.GetModuleCacheDir(),
+		sums:     sums,
+		goMods:   make(map[module.Version]*modfile.File),
+		visited:  make(map[module.Version]bool),
+		selected: make(map[string]string),
+	}
+
+	// Pass 1: perform minimal version selection over the raw require graph.
+	// Replace directives are intentionally not considered here - go only
+	// ever applies the main module's replacements, never a dependency's.
+	for _, req := range mainGoMod.Require {
+		if err := resolver.selectVersion(req.Mod); err != nil {
+			return nil, fmt.Errorf("resolving %s failed: %w", req.Mod.Path, err)
+		}
+	}
+
+	// Pass 2: build a Module per selected path, applying the main module's
+	// replace directives to the selected version.
+	mainModule := &Module{Dir: path, Main: true, Path: mainGoMod.Module.Mod.Path}
+	modulesByPath := map[string]*Module{mainModule.Path: mainModule}
+	for modPath, version := range resolver.selected {
+		modulesByPath[modPath], err = resolver.buildModule(modPath, version, mainGoMod.Replace)
+		if err != nil {
+			return nil, fmt.Errorf("resolving %s failed: %w", modPath, err)
+		}
+	}
+
+	// Pass 3: wire up dependency edges between the selected modules, based on
+	// each module's own (unreplaced) requirements.
+	for _, req := range mainGoMod.Require {
+		mainModule.Dependencies = append(mainModule.Dependencies, modulesByPath[req.Mod.Path])
+	}
+	for modPath, version := range resolver.selected {
+		mod := modulesByPath[modPath]
+		target := mod
+		if mod.Replace != nil {
+			target = mod.Replace
+		}
+		for _, req := range resolver.goMods[module.Version{Path: modPath, Version: version}].Require {
+			target.Dependencies = append(target.Dependencies, modulesByPath[req.Mod.Path])
+		}
+	}
+
+	modules := make([]Module, 0, len(modulesByPath))
+	for _, mod := range modulesByPath {
+		modules = append(modules, *mod)
+	}
+
+	return modules, nil
+}
+
+// offlineResolver performs minimal version selection and module lookups
+// purely from the local module cache.
+type offlineResolver struct {
+	cacheDir string
+	sums     map[string]string
+	goMods   map[module.Version]*modfile.File
+	visited  map[module.Version]bool // exact (path, version) pairs already walked for their own requirements
+	selected map[string]string       // module path -> highest version required anywhere in the graph
+}
+
+// selectVersion records mod as a candidate for its path's selected version,
+// keeping the higher one, and recurses into its own requirements. Real MVS
+// visits every distinct (path, version) that ever appears as a requirement
+// edge, even one a higher version later supersedes - a superseded version can
+// uniquely require some other module that the winning version doesn't - so
+// the "higher version wins" comparison only decides the final selection, not
+// whether mod's own requirements get walked. Each exact (path, version) is
+// still only walked once, memoized via visited.
+func (r *offlineResolver) selectVersion(mod module.Version) error {
+	if current, ok := r.selected[mod.Path]; !ok || semver.Compare(current, mod.Version) < 0 {
+		r.selected[mod.Path] = mod.Version
+	}
+
+	if r.visited[mod] {
+		return nil
+	}
+	r.visited[mod] = true
+
+	depGoMod, err := r.loadGoMod(mod)
+	if err != nil {
+		return err
+	}
+
+	for _, req := range depGoMod.Require {
+		if err := r.selectVersion(req.Mod); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// buildModule returns the Module for modPath at version, applying replace
+// (which must be the main module's replace directives) to it.
+func (r *offlineResolver) buildModule(modPath, version string, replace []*modfile.Replace) (*Module, error) {
+	mod := &Module{Path: modPath, Version: version}
+
+	target := module.Version{Path: modPath, Version: version}
+	for _, rep := range replace {
+		if rep.Old.Path == modPath && (rep.Old.Version == "" || rep.Old.Version == version) {
+			target = rep.New.Mod
+			break
+		}
+	}
+
+	dir, err := r.moduleDir(target)
+	if err != nil {
+		return nil, err
+	}
+	coordinates := target.Path + "@" + target.Version
+
+	if target == (module.Version{Path: modPath, Version: version}) {
+		mod.Dir = dir
+		mod.Sum = r.sums[coordinates]
+	} else {
+		mod.Replace = &Module{Path: target.Path, Version: target.Version, Dir: dir, Sum: r.sums[coordinates]}
+	}
+
+	return mod, nil
+}
+
+// loadGoMod returns the parsed go.mod of mod as found in the module cache's
+// download directory, memoizing it for reuse.
+func (r *offlineResolver) loadGoMod(mod module.Version) (*modfile.File, error) {
+	if cached, ok := r.goMods[mod]; ok {
+		return cached, nil
+	}
+
+	escapedPath, err := module.EscapePath(mod.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	goModPath := filepath.Join(r.cacheDir, "cache", "download", escapedPath, "@v", mod.Version+".mod")
+	goMod, err := parseGoMod(goModPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// Not in the module cache: most likely GOMODCACHE hasn't been
+			// primed for this module, or it predates modules entirely.
+			return nil, fmt.Errorf("%s@%s not found in module cache: %w", mod.Path, mod.Version, err)
+		}
+		return nil, err
+	}
+
+	r.goMods[mod] = goMod
+	return goMod, nil
+}
+
+// moduleDir returns the path module sources are extracted to in the module cache.
+func (r *offlineResolver) moduleDir(mod module.Version) (string, error) {
+	escapedPath, err := module.EscapePath(mod.Path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(r.cacheDir, escapedPath+"@"+mod.Version), nil
+}
+
+// parseGoMod reads and parses the go.mod file at goModPath.
+func parseGoMod(goModPath string) (*modfile.File, error) {
+	data, err := os.ReadFile(goModPath)
+	if err != nil {
+		return nil, err
+	}
+	return modfile.Parse(goModPath, data, nil)
+}
+
+// readGoSum parses a go.sum file into a map of "path@version" to its h1 hash,
+// ignoring the separate "path@version/go.mod" checksum lines.
+func readGoSum(goSumPath string) (map[string]string, error) {
+	file, err := os.Open(goSumPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	sums := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 || strings.HasSuffix(fields[1], "/go.mod") {
+			continue
+		}
+		sums[fields[0]+"@"+fields[1]] = fields[2]
+	}
+
+	return sums, scanner.Err()
+}
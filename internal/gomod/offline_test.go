@@ -0,0 +1,103 @@
+package gomod
+
+import (
+	"testing"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+// requireOf returns a minimal *modfile.File requiring each of mods.
+func requireOf(mods ...module.Version) *modfile.File {
+	file := &modfile.File{}
+	for _, mod := range mods {
+		file.Require = append(file.Require, &modfile.Require{Mod: mod})
+	}
+	return file
+}
+
+func TestOfflineResolverSelectVersion(t *testing.T) {
+	b110 := module.Version{Path: "example.com/b", Version: "v1.1.0"}
+	c100 := module.Version{Path: "example.com/c", Version: "v1.0.0"}
+	c120 := module.Version{Path: "example.com/c", Version: "v1.2.0"}
+
+	newResolver := func() *offlineResolver {
+		return &offlineResolver{
+			goMods: map[module.Version]*modfile.File{
+				// b requires an older c than the main module does; MVS must
+				// still select c's higher version, not both.
+				b110: requireOf(c100),
+				c100: requireOf(),
+				c120: requireOf(),
+			},
+			visited:  make(map[module.Version]bool),
+			selected: make(map[string]string),
+		}
+	}
+
+	t.Run("higher version selected regardless of visit order", func(t *testing.T) {
+		r := newResolver()
+		if err := r.selectVersion(b110); err != nil {
+			t.Fatalf("selectVersion(%v) failed: %v", b110, err)
+		}
+		if err := r.selectVersion(c120); err != nil {
+			t.Fatalf("selectVersion(%v) failed: %v", c120, err)
+		}
+
+		if got := r.selected["example.com/c"]; got != "v1.2.0" {
+			t.Errorf("selected version for example.com/c = %q, want v1.2.0", got)
+		}
+		if got := r.selected["example.com/b"]; got != "v1.1.0" {
+			t.Errorf("selected version for example.com/b = %q, want v1.1.0", got)
+		}
+	})
+
+	t.Run("a later, lower requirement doesn't downgrade an already-selected version", func(t *testing.T) {
+		r := newResolver()
+		if err := r.selectVersion(c120); err != nil {
+			t.Fatalf("selectVersion(%v) failed: %v", c120, err)
+		}
+		if err := r.selectVersion(b110); err != nil {
+			t.Fatalf("selectVersion(%v) failed: %v", b110, err)
+		}
+
+		if got := r.selected["example.com/c"]; got != "v1.2.0" {
+			t.Errorf("selected version for example.com/c = %q, want v1.2.0", got)
+		}
+	})
+
+	t.Run("a superseded version's own requirements are still walked", func(t *testing.T) {
+		// y requires x then z v1.0.0; x requires z v2.0.0, which wins MVS -
+		// but only z v1.0.0 requires w, so w must still turn up in the graph.
+		y := module.Version{Path: "example.com/y", Version: "v1.0.0"}
+		x := module.Version{Path: "example.com/x", Version: "v1.0.0"}
+		z100 := module.Version{Path: "example.com/z", Version: "v1.0.0"}
+		z200 := module.Version{Path: "example.com/z", Version: "v2.0.0"}
+		w100 := module.Version{Path: "example.com/w", Version: "v1.0.0"}
+
+		r := &offlineResolver{
+			goMods: map[module.Version]*modfile.File{
+				y:    requireOf(x, z100),
+				x:    requireOf(z200),
+				z100: requireOf(w100),
+				z200: requireOf(),
+				w100: requireOf(),
+			},
+			visited:  make(map[module.Version]bool),
+			selected: make(map[string]string),
+		}
+
+		if err := r.selectVersion(y); err != nil {
+			t.Fatalf("selectVersion(%v) failed: %v", y, err)
+		}
+
+		if got := r.selected["example.com/z"]; got != "v2.0.0" {
+			t.Errorf("selected version for example.com/z = %q, want v2.0.0", got)
+		}
+		if got, ok := r.selected["example.com/w"]; !ok {
+			t.Error("example.com/w is missing from the graph entirely")
+		} else if got != "v1.0.0" {
+			t.Errorf("selected version for example.com/w = %q, want v1.0.0", got)
+		}
+	})
+}
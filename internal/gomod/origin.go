@@ -0,0 +1,65 @@
+package gomod
+
+import (
+	"fmt"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+
+	"github.com/CycloneDX/cyclonedx-gomod/internal/gomod/vcs"
+)
+
+// Origin captures where a module's version was resolved from, so that a BOM
+// can point back at the exact VCS revision it was built from.
+type Origin struct {
+	VCS        string    `json:"vcs,omitempty"`
+	URL        string    `json:"url,omitempty"`
+	Ref        string    `json:"ref,omitempty"`
+	Hash       string    `json:"hash,omitempty"`
+	CommitTime time.Time `json:"commitTime,omitempty"`
+}
+
+// newOrigin builds an Origin for hash, resolved as ref in repo. ref may be
+// empty when the revision isn't tagged.
+func newOrigin(repo vcs.VCS, hash string, commitTime time.Time, ref string) *Origin {
+	origin := &Origin{
+		VCS:        repo.Kind(),
+		Ref:        ref,
+		Hash:       hash,
+		CommitTime: commitTime.UTC(),
+	}
+
+	if url, err := repo.RemoteURL(); err == nil {
+		origin.URL = url
+	}
+
+	return origin
+}
+
+// ExternalReference returns the CycloneDX external reference pointing back at
+// the exact VCS revision o was resolved from, or nil if o has no URL to
+// point at.
+func (o *Origin) ExternalReference() *cdx.ExternalReference {
+	if o == nil || o.URL == "" {
+		return nil
+	}
+
+	return &cdx.ExternalReference{
+		Type:    cdx.ERTypeVCS,
+		URL:     o.URL,
+		Comment: fmt.Sprintf("Resolved from %s commit %s", o.VCS, o.Hash),
+	}
+}
+
+// Property returns a CycloneDX property recording the commit time o was
+// resolved from, or nil if o has no commit time set.
+func (o *Origin) Property() *cdx.Property {
+	if o == nil || o.CommitTime.IsZero() {
+		return nil
+	}
+
+	return &cdx.Property{
+		Name:  "cdx:gomod:commitTime",
+		Value: o.CommitTime.Format(time.RFC3339),
+	}
+}
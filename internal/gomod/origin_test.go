@@ -0,0 +1,148 @@
+package gomod
+
+import (
+	"testing"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+func TestOriginExternalReference(t *testing.T) {
+	tests := []struct {
+		name   string
+		origin *Origin
+		want   *cdx.ExternalReference
+	}{
+		{
+			name:   "nil origin",
+			origin: nil,
+			want:   nil,
+		},
+		{
+			name:   "no URL resolved",
+			origin: &Origin{VCS: "git", Hash: "abcdefabcdef"},
+			want:   nil,
+		},
+		{
+			name: "url and hash present",
+			origin: &Origin{
+				VCS:  "git",
+				URL:  "https://example.com/foo.git",
+				Hash: "abcdefabcdef0123456789",
+			},
+			want: &cdx.ExternalReference{
+				Type:    cdx.ERTypeVCS,
+				URL:     "https://example.com/foo.git",
+				Comment: "Resolved from git commit abcdefabcdef0123456789",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.origin.ExternalReference()
+			if tt.want == nil {
+				if got != nil {
+					t.Fatalf("ExternalReference() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("ExternalReference() = nil, want non-nil")
+			}
+			if *got != *tt.want {
+				t.Errorf("ExternalReference() = %+v, want %+v", *got, *tt.want)
+			}
+		})
+	}
+}
+
+func TestOriginProperty(t *testing.T) {
+	commitTime := time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		origin *Origin
+		want   *cdx.Property
+	}{
+		{
+			name:   "nil origin",
+			origin: nil,
+			want:   nil,
+		},
+		{
+			name:   "zero commit time",
+			origin: &Origin{VCS: "git"},
+			want:   nil,
+		},
+		{
+			name:   "commit time present",
+			origin: &Origin{VCS: "git", CommitTime: commitTime},
+			want: &cdx.Property{
+				Name:  "cdx:gomod:commitTime",
+				Value: "2021-01-02T15:04:05Z",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.origin.Property()
+			if tt.want == nil {
+				if got != nil {
+					t.Fatalf("Property() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("Property() = nil, want non-nil")
+			}
+			if *got != *tt.want {
+				t.Errorf("Property() = %+v, want %+v", *got, *tt.want)
+			}
+		})
+	}
+}
+
+func TestModuleExternalReferencesAndProperties(t *testing.T) {
+	t.Run("nil origin yields no references or properties", func(t *testing.T) {
+		m := Module{Path: "example.com/mod", Version: "v1.0.0"}
+
+		if refs := m.ExternalReferences(); refs != nil {
+			t.Errorf("ExternalReferences() = %+v, want nil", refs)
+		}
+		if props := m.Properties(); props != nil {
+			t.Errorf("Properties() = %+v, want nil", props)
+		}
+	})
+
+	t.Run("origin with URL and commit time populates both", func(t *testing.T) {
+		commitTime := time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC)
+		m := Module{
+			Path:    "example.com/mod",
+			Version: "v1.0.0",
+			Origin: &Origin{
+				VCS:        "git",
+				URL:        "https://example.com/mod.git",
+				Hash:       "abcdefabcdef0123456789",
+				CommitTime: commitTime,
+			},
+		}
+
+		refs := m.ExternalReferences()
+		if len(refs) != 1 {
+			t.Fatalf("ExternalReferences() = %+v, want 1 entry", refs)
+		}
+		if refs[0].Type != cdx.ERTypeVCS {
+			t.Errorf("ExternalReferences()[0].Type = %q, want %q", refs[0].Type, cdx.ERTypeVCS)
+		}
+
+		props := m.Properties()
+		if len(props) != 1 {
+			t.Fatalf("Properties() = %+v, want 1 entry", props)
+		}
+		if props[0].Name != "cdx:gomod:commitTime" {
+			t.Errorf("Properties()[0].Name = %q, want cdx:gomod:commitTime", props[0].Name)
+		}
+	})
+}
@@ -0,0 +1,155 @@
+package vcs
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// bazaar shells out to the bzr binary.
+type bazaar struct {
+	dir string
+}
+
+func newBazaar(dir string) VCS {
+	return &bazaar{dir: dir}
+}
+
+func (b *bazaar) Kind() string {
+	return "bzr"
+}
+
+func (b *bazaar) Head() (string, time.Time, error) {
+	revno, err := b.run("revno", "--tree")
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	out, err := b.run("log", "--show-ids", "-r", revno)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	commitTime, err := parseBzrLogTimestamp(out)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return revno, commitTime, nil
+}
+
+// parseBzrLogTimestamp extracts the commit time from the "timestamp:" line of
+// `bzr log --show-ids` output, e.g. "timestamp: Sat 2021-01-02 15:04:05 +0000".
+func parseBzrLogTimestamp(log string) (time.Time, error) {
+	var timestamp string
+	for _, line := range strings.Split(log, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(key) != "timestamp" {
+			continue
+		}
+		timestamp = strings.TrimSpace(value)
+		break
+	}
+	if timestamp == "" {
+		return time.Time{}, fmt.Errorf("could not find timestamp in bzr log output: %s", log)
+	}
+
+	commitTime, err := time.Parse("Mon 2006-01-02 15:04:05 -0700", timestamp)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing bzr commit time failed: %w", err)
+	}
+
+	return commitTime.UTC(), nil
+}
+
+func (b *bazaar) TagsAt(hash string) ([]string, error) {
+	var tags []string
+	for tag, revno := range b.tagsByRevno() {
+		if revno == hash {
+			tags = append(tags, tag)
+		}
+	}
+	return filterSemverTags(tags), nil
+}
+
+func (b *bazaar) AncestorTags(hash string) ([]string, error) {
+	headRevno, err := strconv.Atoi(hash)
+	if err != nil {
+		return nil, fmt.Errorf("bzr revision %q is not numeric: %w", hash, err)
+	}
+
+	var tags []string
+	for tag, revnoStr := range b.tagsByRevno() {
+		revno, err := strconv.Atoi(revnoStr)
+		if err != nil || revno >= headRevno {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	return filterSemverTags(tags), nil
+}
+
+func (b *bazaar) ResolveTag(tag string) (string, error) {
+	revno, ok := b.tagsByRevno()[tag]
+	if !ok {
+		return "", fmt.Errorf("bzr tag %q not found", tag)
+	}
+	return revno, nil
+}
+
+func (b *bazaar) FileExistsAt(hash, relPath string) (bool, error) {
+	if _, err := b.run("cat", "-r", hash, relPath); err != nil {
+		return false, nil
+	}
+	return true, nil
+}
+
+func (b *bazaar) Dirty() (bool, error) {
+	out, err := b.run("status", "--short")
+	if err != nil {
+		return false, err
+	}
+	return out != "", nil
+}
+
+func (b *bazaar) RemoteURL() (string, error) {
+	return b.run("config", "parent_location")
+}
+
+// tagsByRevno maps tag name to the revision number it points at. Errors are
+// swallowed since tags are an optional nicety here, not load-bearing.
+func (b *bazaar) tagsByRevno() map[string]string {
+	out, err := b.run("tags")
+	if err != nil {
+		return nil
+	}
+	return parseBzrTags(out)
+}
+
+// parseBzrTags parses the two-column output of `bzr tags` ("<tag> <revno>"
+// per line) into a map of tag name to revision number.
+func parseBzrTags(out string) map[string]string {
+	tagsByRevno := make(map[string]string)
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		tagsByRevno[fields[0]] = fields[1]
+	}
+	return tagsByRevno
+}
+
+func (b *bazaar) run(args ...string) (string, error) {
+	cmd := exec.Command("bzr", args...)
+	cmd.Dir = b.dir
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("bzr %s: %w", strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
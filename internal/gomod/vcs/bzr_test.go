@@ -0,0 +1,105 @@
+package vcs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseBzrLogTimestamp(t *testing.T) {
+	tests := []struct {
+		name     string
+		log      string
+		wantTime time.Time
+		wantErr  bool
+	}{
+		{
+			name: "typical log --show-ids output",
+			log: "revno: 42\n" +
+				"revision-id: example@host-20210102150405-abcdef0123456789\n" +
+				"committer: Jane Doe <jane@example.com>\n" +
+				"timestamp: Sat 2021-01-02 15:04:05 +0000\n" +
+				"message:\n  Example commit\n",
+			wantTime: time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC),
+		},
+		{
+			name:     "non-UTC offset is normalized to UTC",
+			log:      "timestamp: Sat 2021-01-02 10:04:05 -0500\n",
+			wantTime: time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC),
+		},
+		{
+			name:    "no timestamp line",
+			log:     "revno: 42\n",
+			wantErr: true,
+		},
+		{
+			name:    "malformed timestamp",
+			log:     "timestamp: not a valid time\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseBzrLogTimestamp(tt.log)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseBzrLogTimestamp failed: %v", err)
+			}
+			if !got.Equal(tt.wantTime) {
+				t.Errorf("parseBzrLogTimestamp() = %v, want %v", got, tt.wantTime)
+			}
+		})
+	}
+}
+
+func TestParseBzrTags(t *testing.T) {
+	tests := []struct {
+		name string
+		out  string
+		want map[string]string
+	}{
+		{
+			name: "two tags",
+			out:  "v1.0.0  10\nv1.1.0  20\n",
+			want: map[string]string{"v1.0.0": "10", "v1.1.0": "20"},
+		},
+		{
+			name: "tag with unresolvable revision passes through verbatim",
+			// bzr prints "?" as the revno for a tag that doesn't exist in the
+			// branch's current history. parseBzrTags doesn't special-case
+			// this - the literal "?" ends up as the revno - callers that care
+			// (AncestorTags) reject it themselves via strconv.Atoi.
+			out:  "v1.0.0  10\nv1.1.0  ?\n",
+			want: map[string]string{"v1.0.0": "10", "v1.1.0": "?"},
+		},
+		{
+			name: "line with an unexpected field count is dropped",
+			out:  "v1.0.0  10\nstray line with three fields\n",
+			want: map[string]string{"v1.0.0": "10"},
+		},
+		{
+			name: "empty output",
+			out:  "",
+			want: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseBzrTags(tt.out)
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseBzrTags() = %v, want %v", got, tt.want)
+			}
+			for tag, revno := range tt.want {
+				if got[tag] != revno {
+					t.Errorf("parseBzrTags()[%q] = %q, want %q", tag, got[tag], revno)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package vcs
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// fossil shells out to the fossil binary.
+type fossil struct {
+	dir string
+}
+
+func newFossil(dir string) VCS {
+	return &fossil{dir: dir}
+}
+
+func (f *fossil) Kind() string {
+	return "fossil"
+}
+
+func (f *fossil) Head() (string, time.Time, error) {
+	out, err := f.run("info")
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return parseFossilCheckout(out)
+}
+
+// parseFossilCheckout extracts the checkout hash and commit time from the
+// "checkout:" line of `fossil info` output, e.g.:
+//
+//	checkout:     1a2b3c4d5e6f 2021-01-02 15:04:05 UTC
+func parseFossilCheckout(info string) (string, time.Time, error) {
+	var hash, checkoutTime string
+	for _, line := range strings.Split(info, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(key) != "checkout" {
+			continue
+		}
+		fields := strings.Fields(strings.TrimSpace(value))
+		if len(fields) < 3 {
+			continue
+		}
+		hash = fields[0]
+		checkoutTime = fields[1] + " " + fields[2]
+	}
+	if hash == "" {
+		return "", time.Time{}, fmt.Errorf("could not determine fossil checkout hash from: %s", info)
+	}
+
+	commitTime, err := time.Parse("2006-01-02 15:04:05", checkoutTime)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("parsing fossil checkout time failed: %w", err)
+	}
+
+	return hash, commitTime.UTC(), nil
+}
+
+func (f *fossil) TagsAt(hash string) ([]string, error) {
+	out, err := f.run("tag", "list", hash)
+	if err != nil {
+		return nil, err
+	}
+	return filterSemverTags(strings.Fields(out)), nil
+}
+
+// AncestorTags walks the parent chain of hash (following merges too) via
+// repeated `fossil info` calls, and returns the tags found on any proper
+// ancestor. fossil has no single query for "tags reachable from here", so
+// this has to be assembled by hand.
+func (f *fossil) AncestorTags(hash string) ([]string, error) {
+	visited := map[string]bool{hash: true}
+	queue := []string{hash}
+
+	var tags []string
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current != hash {
+			currentTags, err := f.TagsAt(current)
+			if err != nil {
+				return nil, err
+			}
+			tags = append(tags, currentTags...)
+		}
+
+		parents, err := f.parentsOf(current)
+		if err != nil {
+			return nil, err
+		}
+		for _, parent := range parents {
+			if !visited[parent] {
+				visited[parent] = true
+				queue = append(queue, parent)
+			}
+		}
+	}
+
+	return tags, nil
+}
+
+// parentsOf returns the parent check-in hashes of hash, as reported in the
+// "parent:" lines of `fossil info`.
+func (f *fossil) parentsOf(hash string) ([]string, error) {
+	out, err := f.run("info", hash)
+	if err != nil {
+		return nil, err
+	}
+	return parseFossilParents(out), nil
+}
+
+// parseFossilParents extracts the check-in hashes from the "parent:" lines of
+// `fossil info` output. A merge check-in has multiple such lines.
+func parseFossilParents(info string) []string {
+	var parents []string
+	for _, line := range strings.Split(info, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(key) != "parent" {
+			continue
+		}
+		fields := strings.Fields(strings.TrimSpace(value))
+		if len(fields) > 0 {
+			parents = append(parents, fields[0])
+		}
+	}
+	return parents
+}
+
+func (f *fossil) ResolveTag(tag string) (string, error) {
+	out, err := f.run("info", tag)
+	if err != nil {
+		return "", err
+	}
+
+	if hash := parseFossilInfoHash(out); hash != "" {
+		return hash, nil
+	}
+
+	return "", fmt.Errorf("could not resolve fossil tag %s", tag)
+}
+
+// parseFossilInfoHash extracts the check-in hash from the "hash:" or
+// "checkout:" line of `fossil info` output, or returns "" if neither is
+// present.
+func parseFossilInfoHash(info string) string {
+	for _, line := range strings.Split(info, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		if key := strings.TrimSpace(key); key == "hash" || key == "checkout" {
+			fields := strings.Fields(strings.TrimSpace(value))
+			if len(fields) > 0 {
+				return fields[0]
+			}
+		}
+	}
+	return ""
+}
+
+func (f *fossil) FileExistsAt(hash, relPath string) (bool, error) {
+	if _, err := f.run("cat", relPath, "-r", hash); err != nil {
+		return false, nil
+	}
+	return true, nil
+}
+
+func (f *fossil) Dirty() (bool, error) {
+	out, err := f.run("changes")
+	if err != nil {
+		return false, err
+	}
+	return out != "", nil
+}
+
+func (f *fossil) RemoteURL() (string, error) {
+	return f.run("remote-url")
+}
+
+func (f *fossil) run(args ...string) (string, error) {
+	cmd := exec.Command("fossil", args...)
+	cmd.Dir = f.dir
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("fossil %s: %w", strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
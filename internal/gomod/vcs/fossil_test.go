@@ -0,0 +1,129 @@
+package vcs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseFossilCheckout(t *testing.T) {
+	tests := []struct {
+		name     string
+		info     string
+		wantHash string
+		wantTime time.Time
+		wantErr  bool
+	}{
+		{
+			name: "typical info output",
+			info: "project-name: example\n" +
+				"checkout:     1a2b3c4d5e6f 2021-01-02 15:04:05 UTC\n" +
+				"parent:       0123456789ab 2021-01-01 10:00:00 UTC\n",
+			wantHash: "1a2b3c4d5e6f",
+			wantTime: time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC),
+		},
+		{
+			name:    "no checkout line",
+			info:    "project-name: example\n",
+			wantErr: true,
+		},
+		{
+			name:    "checkout line missing time fields",
+			info:    "checkout:     1a2b3c4d5e6f\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, commitTime, err := parseFossilCheckout(tt.info)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got hash %q", hash)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseFossilCheckout failed: %v", err)
+			}
+			if hash != tt.wantHash {
+				t.Errorf("hash = %q, want %q", hash, tt.wantHash)
+			}
+			if !commitTime.Equal(tt.wantTime) {
+				t.Errorf("commitTime = %v, want %v", commitTime, tt.wantTime)
+			}
+		})
+	}
+}
+
+func TestParseFossilParents(t *testing.T) {
+	tests := []struct {
+		name string
+		info string
+		want []string
+	}{
+		{
+			name: "single parent",
+			info: "checkout:    1a2b3c4d5e6f 2021-01-02 15:04:05 UTC\n" +
+				"parent:      0123456789ab 2021-01-01 10:00:00 UTC\n",
+			want: []string{"0123456789ab"},
+		},
+		{
+			name: "merge check-in with two parents",
+			info: "checkout:    1a2b3c4d5e6f 2021-01-02 15:04:05 UTC\n" +
+				"parent:      0123456789ab 2021-01-01 10:00:00 UTC\n" +
+				"parent:      deadbeefcafe 2020-12-31 09:00:00 UTC (merge)\n",
+			want: []string{"0123456789ab", "deadbeefcafe"},
+		},
+		{
+			name: "no parents (root check-in)",
+			info: "checkout:    1a2b3c4d5e6f 2021-01-02 15:04:05 UTC\n",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseFossilParents(tt.info)
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseFossilParents() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("parseFossilParents()[%d] = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseFossilInfoHash(t *testing.T) {
+	tests := []struct {
+		name string
+		info string
+		want string
+	}{
+		{
+			name: "hash line",
+			info: "hash:         1a2b3c4d5e6f8a9b0c1d2e3f4a5b6c7d8e9f0a1b\n",
+			want: "1a2b3c4d5e6f8a9b0c1d2e3f4a5b6c7d8e9f0a1b",
+		},
+		{
+			name: "checkout line, no hash line",
+			info: "checkout:     1a2b3c4d5e6f 2021-01-02 15:04:05 UTC\n",
+			want: "1a2b3c4d5e6f",
+		},
+		{
+			name: "neither present",
+			info: "project-name: example\n",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseFossilInfoHash(tt.info); got != tt.want {
+				t.Errorf("parseFossilInfoHash() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
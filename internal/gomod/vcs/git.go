@@ -0,0 +1,178 @@
+package vcs
+
+import (
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+type gitVCS struct {
+	repo *git.Repository
+}
+
+func newGit(dir string) (VCS, error) {
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &gitVCS{repo: repo}, nil
+}
+
+func (g *gitVCS) Kind() string {
+	return "git"
+}
+
+func (g *gitVCS) Head() (string, time.Time, error) {
+	headRef, err := g.repo.Head()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	commit, err := g.repo.CommitObject(headRef.Hash())
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return commit.Hash.String(), commit.Committer.When.UTC(), nil
+}
+
+func (g *gitVCS) TagsAt(hash string) ([]string, error) {
+	tagsByHash, err := g.tagsByHash()
+	if err != nil {
+		return nil, err
+	}
+	return tagsByHash[hash], nil
+}
+
+func (g *gitVCS) AncestorTags(hash string) ([]string, error) {
+	tagsByHash, err := g.tagsByHash()
+	if err != nil {
+		return nil, err
+	}
+
+	headHash := plumbing.NewHash(hash)
+	commitIter, err := g.repo.Log(&git.LogOptions{From: headHash})
+	if err != nil {
+		return nil, err
+	}
+	defer commitIter.Close()
+
+	var tags []string
+	err = commitIter.ForEach(func(c *object.Commit) error {
+		if c.Hash == headHash {
+			// Only consider proper ancestors, not hash itself.
+			return nil
+		}
+		tags = append(tags, tagsByHash[c.Hash.String()]...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (g *gitVCS) ResolveTag(tag string) (string, error) {
+	ref, err := g.repo.Tag(tag)
+	if err != nil {
+		return "", err
+	}
+
+	hash := ref.Hash()
+	if tagObj, err := g.repo.TagObject(hash); err == nil {
+		// Annotated tag: resolve to the commit it points at.
+		hash = tagObj.Target
+	}
+
+	return hash.String(), nil
+}
+
+func (g *gitVCS) FileExistsAt(hash, relPath string) (bool, error) {
+	commit, err := g.repo.CommitObject(plumbing.NewHash(hash))
+	if err != nil {
+		return false, err
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := tree.File(relPath); err != nil {
+		if err == object.ErrFileNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (g *gitVCS) Dirty() (bool, error) {
+	worktree, err := g.repo.Worktree()
+	if err != nil {
+		return false, err
+	}
+
+	status, err := worktree.Status()
+	if err != nil {
+		return false, err
+	}
+
+	return !status.IsClean(), nil
+}
+
+func (g *gitVCS) RemoteURL() (string, error) {
+	remote, err := g.repo.Remote("origin")
+	if err != nil {
+		// No "origin" remote configured isn't an error worth failing on.
+		return "", nil
+	}
+
+	urls := remote.Config().URLs
+	if len(urls) == 0 {
+		return "", nil
+	}
+
+	return urls[0], nil
+}
+
+// tagsByHash indexes all semver tags by the hash of the commit they point at,
+// resolving annotated tags to the commit they ultimately reference.
+func (g *gitVCS) tagsByHash() (map[string][]string, error) {
+	tags, err := g.repo.Tags()
+	if err != nil {
+		return nil, err
+	}
+
+	tagsByHash := make(map[string][]string)
+	err = tags.ForEach(func(reference *plumbing.Reference) error {
+		if !strings.HasPrefix(reference.Name().String(), "refs/tags/v") {
+			return nil
+		}
+
+		tagName := strings.TrimPrefix(reference.Name().String(), "refs/tags/")
+
+		hash := reference.Hash()
+		if tagObj, err := g.repo.TagObject(hash); err == nil {
+			// Annotated tag: resolve to the commit it points at.
+			hash = tagObj.Target
+		}
+
+		tagsByHash[hash.String()] = append(tagsByHash[hash.String()], tagName)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for hash, tags := range tagsByHash {
+		tagsByHash[hash] = filterSemverTags(tags)
+	}
+
+	return tagsByHash, nil
+}
@@ -0,0 +1,102 @@
+package vcs
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// mercurial shells out to the hg binary, since there's no well-maintained
+// pure Go Mercurial implementation comparable to go-git.
+type mercurial struct {
+	dir string
+}
+
+func newMercurial(dir string) VCS {
+	return &mercurial{dir: dir}
+}
+
+func (m *mercurial) Kind() string {
+	return "hg"
+}
+
+func (m *mercurial) Head() (string, time.Time, error) {
+	out, err := m.run("log", "-r", ".", "-T", "{node}\t{date|hgdate}")
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return parseHgLogNodeAndDate(out)
+}
+
+// parseHgLogNodeAndDate parses the output of an `hg log -T "{node}\t{date|hgdate}"`
+// query, where {date|hgdate} renders as "<unix seconds> <utc offset>".
+func parseHgLogNodeAndDate(out string) (string, time.Time, error) {
+	fields := strings.SplitN(out, "\t", 2)
+	if len(fields) != 2 {
+		return "", time.Time{}, fmt.Errorf("unexpected hg log output: %s", out)
+	}
+
+	seconds, err := strconv.ParseInt(strings.Fields(fields[1])[0], 10, 64)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("parsing hg commit time failed: %w", err)
+	}
+
+	return fields[0], time.Unix(seconds, 0).UTC(), nil
+}
+
+func (m *mercurial) TagsAt(hash string) ([]string, error) {
+	out, err := m.run("log", "-r", hash, "-T", "{tags}")
+	if err != nil {
+		return nil, err
+	}
+	return filterSemverTags(strings.Fields(out)), nil
+}
+
+func (m *mercurial) AncestorTags(hash string) ([]string, error) {
+	out, err := m.run("log", "-r", fmt.Sprintf("ancestors(%s) - %s", hash, hash), "-T", "{tags}\n")
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []string
+	for _, line := range strings.Split(out, "\n") {
+		tags = append(tags, filterSemverTags(strings.Fields(line))...)
+	}
+	return tags, nil
+}
+
+func (m *mercurial) ResolveTag(tag string) (string, error) {
+	return m.run("log", "-r", tag, "-T", "{node}")
+}
+
+func (m *mercurial) FileExistsAt(hash, relPath string) (bool, error) {
+	if _, err := m.run("files", "-r", hash, relPath); err != nil {
+		return false, nil
+	}
+	return true, nil
+}
+
+func (m *mercurial) Dirty() (bool, error) {
+	out, err := m.run("status")
+	if err != nil {
+		return false, err
+	}
+	return out != "", nil
+}
+
+func (m *mercurial) RemoteURL() (string, error) {
+	return m.run("paths", "default")
+}
+
+func (m *mercurial) run(args ...string) (string, error) {
+	cmd := exec.Command("hg", append([]string{"-R", m.dir}, args...)...)
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("hg %s: %w", strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,54 @@
+package vcs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseHgLogNodeAndDate(t *testing.T) {
+	tests := []struct {
+		name     string
+		out      string
+		wantNode string
+		wantTime time.Time
+		wantErr  bool
+	}{
+		{
+			name:     "node and hgdate with positive offset",
+			out:      "1a2b3c4d5e6f0123456789abcdef0123456789ab\t1609599845 -3600",
+			wantNode: "1a2b3c4d5e6f0123456789abcdef0123456789ab",
+			wantTime: time.Unix(1609599845, 0).UTC(),
+		},
+		{
+			name:    "missing tab separator",
+			out:     "1a2b3c4d5e6f0123456789abcdef0123456789ab 1609599845 -3600",
+			wantErr: true,
+		},
+		{
+			name:    "non-numeric seconds",
+			out:     "1a2b3c4d5e6f0123456789abcdef0123456789ab\tnot-a-number -3600",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, commitTime, err := parseHgLogNodeAndDate(tt.out)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got node %q", node)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseHgLogNodeAndDate failed: %v", err)
+			}
+			if node != tt.wantNode {
+				t.Errorf("node = %q, want %q", node, tt.wantNode)
+			}
+			if !commitTime.Equal(tt.wantTime) {
+				t.Errorf("commitTime = %v, want %v", commitTime, tt.wantTime)
+			}
+		})
+	}
+}
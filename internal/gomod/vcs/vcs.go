@@ -0,0 +1,93 @@
+// Package vcs abstracts over the version control systems that a Go module's
+// version can be derived from. Git is the common case and is handled via
+// go-git, but replace directives may point at working trees managed by other
+// systems, which is why this package exists.
+package vcs
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"golang.org/x/mod/semver"
+)
+
+// ErrNotFound indicates that no supported VCS checkout could be found at or
+// above a given directory.
+var ErrNotFound = errors.New("no supported VCS checkout found")
+
+// VCS abstracts over a version control system's working copy, providing just
+// enough information to derive a Go module pseudo-version from it.
+type VCS interface {
+	// Kind returns a short, lowercase identifier of the VCS, e.g. "git".
+	Kind() string
+
+	// Head returns the hash and commit time of the currently checked out revision.
+	Head() (hash string, commitTime time.Time, err error)
+
+	// TagsAt returns the semver tags pointing directly at hash.
+	TagsAt(hash string) ([]string, error)
+
+	// AncestorTags returns the semver tags reachable from hash, excluding any
+	// tag pointing at hash itself.
+	AncestorTags(hash string) ([]string, error)
+
+	// ResolveTag returns the hash of the commit tag points at.
+	ResolveTag(tag string) (hash string, err error)
+
+	// FileExistsAt reports whether relPath exists in the tree at hash.
+	FileExistsAt(hash, relPath string) (bool, error)
+
+	// Dirty reports whether the working tree has uncommitted changes.
+	Dirty() (bool, error)
+
+	// RemoteURL returns the fetch URL of the primary remote, if any is configured.
+	RemoteURL() (string, error)
+}
+
+// Detect walks dir and its ancestors looking for a VCS checkout, returning the
+// first backend it recognizes.
+func Detect(dir string) (VCS, error) {
+	for current := dir; ; {
+		if isDir(filepath.Join(current, ".git")) {
+			return newGit(current)
+		}
+		if isDir(filepath.Join(current, ".hg")) {
+			return newMercurial(current), nil
+		}
+		if isDir(filepath.Join(current, ".bzr")) {
+			return newBazaar(current), nil
+		}
+		if isDir(filepath.Join(current, "_FOSSIL_")) || isFile(filepath.Join(current, ".fslckout")) {
+			return newFossil(current), nil
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return nil, ErrNotFound
+		}
+		current = parent
+	}
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// filterSemverTags drops any tag that isn't a valid, canonical semver tag.
+func filterSemverTags(tags []string) []string {
+	filtered := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if semver.IsValid(tag) && semver.Canonical(tag) == tag {
+			filtered = append(filtered, tag)
+		}
+	}
+	return filtered
+}
@@ -1,89 +1,208 @@
 package gomod
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
-	"github.com/go-git/go-git/v5"
-	"github.com/go-git/go-git/v5/plumbing"
-	"github.com/go-git/go-git/v5/plumbing/storer"
+	"golang.org/x/mod/semver"
 
-	"github.com/CycloneDX/cyclonedx-gomod/internal/util"
+	"github.com/CycloneDX/cyclonedx-gomod/internal/gomod/vcs"
 )
 
-// GetModuleVersion attempts to detect a given module's version by first
-// calling GetVersionFromTag and if that fails, GetPseudoVersion on it.
-func GetModuleVersion(modulePath string) (string, error) {
-	if tagVersion, err := GetVersionFromTag(modulePath); err != nil {
-		// TODO: Log err in DEBUG / verbose level
-		pseudoVersion, err := GetPseudoVersion(modulePath)
-		if err != nil {
-			return "", err
-		}
-		return pseudoVersion, nil
-	} else {
-		return tagVersion, nil
-	}
+// ErrDirtyWorktree indicates that a module's worktree has uncommitted changes,
+// making it impossible to reliably derive a version for it.
+var ErrDirtyWorktree = errors.New("worktree is dirty")
+
+// ErrNoTag indicates that the checked out revision isn't tagged.
+var ErrNoTag = errors.New("no tag found for revision")
+
+// GetModuleVersion attempts to detect a given module's version.
+// GetPseudoVersion already accounts for the module being exactly on a tagged
+// commit, so there's no need to fall back to GetVersionFromTag separately.
+//
+// importPath is the module's logical import path (as opposed to modulePath,
+// which is the path to its working copy) and is used to determine whether
+// the resolved version requires a "+incompatible" build tag.
+func GetModuleVersion(modulePath, importPath string) (string, *Origin, error) {
+	return GetPseudoVersion(modulePath, importPath)
 }
 
 // GetPseudoVersion constructs a pseudo version for a Go module at a given path.
-// Note that this is only possible when path points to a Git repository.
-// See https://golang.org/ref/mod#pseudo-versions
-func GetPseudoVersion(modulePath string) (string, error) {
-	repo, err := git.PlainOpen(modulePath)
+// modulePath must point at a working copy of a supported VCS (currently Git,
+// Mercurial, Bazaar and Fossil; see the vcs package).
+//
+// Ancestors of the checked out revision are walked for the highest semver
+// tag. If that revision is exactly the tagged one, the tag is returned
+// as-is. Otherwise, a pseudo-version is derived from it per
+// https://golang.org/ref/mod#pseudo-versions:
+//
+//	vX.Y.(Z+1)-0.yyyymmddhhmmss-abcdefabcdef    (tag has no prerelease)
+//	vX.Y.Z-pre.0.yyyymmddhhmmss-abcdefabcdef    (tag has a prerelease)
+//	v0.0.0-yyyymmddhhmmss-abcdefabcdef          (no tag found)
+//
+// Versions of modules with a major version of 2 or higher that don't declare
+// a matching major version suffix are annotated with "+incompatible".
+//
+// Alongside the version, the Origin it was resolved from is returned, so
+// callers can record a reproducible pointer back to the exact source revision.
+func GetPseudoVersion(modulePath, importPath string) (string, *Origin, error) {
+	repo, err := vcs.Detect(modulePath)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return pseudoVersionOf(repo, importPath)
+}
+
+// pseudoVersionOf holds GetPseudoVersion's logic, parameterized over the VCS
+// backend so it can be exercised against a fake one in tests.
+func pseudoVersionOf(repo vcs.VCS, importPath string) (string, *Origin, error) {
+	dirty, err := repo.Dirty()
+	if err != nil {
+		return "", nil, err
+	}
+	if dirty {
+		return "", nil, ErrDirtyWorktree
+	}
+
+	headHash, commitTime, err := repo.Head()
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
-	headRef, err := repo.Head()
+	headTags, err := repo.TagsAt(headHash)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
-	headCommit, err := repo.CommitObject(headRef.Hash())
+	if tag := highestSemverTag(headTags); tag != "" {
+		version := tag + incompatibleSuffix(repo, importPath, semver.Major(tag), headHash)
+		return version, newOrigin(repo, headHash, commitTime, tag), nil
+	}
+
+	ancestorTags, err := repo.AncestorTags(headHash)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
+	baseTag := highestSemverTag(ancestorTags)
+
+	commitHash := shortHash(headHash)
+	commitDate := commitTime.UTC().Format("20060102150405")
+	origin := newOrigin(repo, headHash, commitTime, "")
 
-	commitHash := headCommit.Hash.String()[:12]
-	commitDate := headCommit.Author.When.Format("20060102150405")
+	if baseTag == "" {
+		return fmt.Sprintf("v0.0.0-%s-%s", commitDate, commitHash), origin, nil
+	}
+
+	// The +incompatible decision is about the pseudo-version's own revision,
+	// not baseTag's - go.mod may have been added or removed since baseTag was
+	// cut, so check headHash rather than resolving baseTag's commit.
+	incompatible := incompatibleSuffix(repo, importPath, semver.Major(baseTag), headHash)
+
+	majorMinorPatch, prerelease := splitSemver(baseTag)
+	if prerelease == "" {
+		nextPatch, err := incrementPatch(majorMinorPatch)
+		if err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("%s-0.%s-%s%s", nextPatch, commitDate, commitHash, incompatible), origin, nil
+	}
 
-	return fmt.Sprintf("v0.0.0-%s-%s", commitDate, commitHash), nil
+	return fmt.Sprintf("%s-%s.0.%s-%s%s", majorMinorPatch, prerelease, commitDate, commitHash, incompatible), origin, nil
 }
 
-// GetVersionFromTag checks if the current commit is annotated with a tag and if yes, returns that tag's name.
-// Note that this is only possible when path points to a Git repository.
-func GetVersionFromTag(modulePath string) (string, error) {
-	repo, err := git.PlainOpen(modulePath)
+// GetVersionFromTag checks if the checked out revision is tagged and if yes,
+// returns that tag's name, annotated with "+incompatible" where applicable.
+func GetVersionFromTag(modulePath, importPath string) (string, *Origin, error) {
+	repo, err := vcs.Detect(modulePath)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
-	headRef, err := repo.Head()
+	return versionFromTagOf(repo, importPath)
+}
+
+// versionFromTagOf holds GetVersionFromTag's logic, parameterized over the
+// VCS backend so it can be exercised against a fake one in tests.
+func versionFromTagOf(repo vcs.VCS, importPath string) (string, *Origin, error) {
+	headHash, commitTime, err := repo.Head()
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
-	tags, err := repo.Tags()
+	tags, err := repo.TagsAt(headHash)
 	if err != nil {
-		return "", err
+		return "", nil, err
+	}
+
+	tag := highestSemverTag(tags)
+	if tag == "" {
+		return "", nil, ErrNoTag
+	}
+
+	version := tag + incompatibleSuffix(repo, importPath, semver.Major(tag), headHash)
+	return version, newOrigin(repo, headHash, commitTime, tag), nil
+}
+
+// incompatibleSuffix returns "+incompatible" when major is v2 or higher,
+// importPath doesn't carry a matching "/vN" suffix, and no go.mod exists at
+// hash. A go.mod at hash means the tag is module-aware but misversioned - the
+// go command rejects that as an invalid version rather than annotating it
+// "+incompatible" - so this function errs on the side of not claiming
+// +incompatible in that case.
+// See https://golang.org/ref/mod#incompatible-versions
+func incompatibleSuffix(repo vcs.VCS, importPath, major, hash string) string {
+	if semver.Compare(major, "v2") < 0 {
+		return ""
+	}
+	if strings.HasSuffix(importPath, "/"+major) {
+		return ""
+	}
+
+	hasGoMod, err := repo.FileExistsAt(hash, "go.mod")
+	if err != nil || hasGoMod {
+		return ""
 	}
 
-	tagName := ""
-	err = tags.ForEach(func(reference *plumbing.Reference) error {
-		if reference.Hash() == headRef.Hash() && util.StartsWith(reference.Name().String(), "refs/tags/v") {
-			tagName = strings.TrimPrefix(reference.Name().String(), "refs/tags/")
-			return storer.ErrStop // break
+	return "+incompatible"
+}
+
+// highestSemverTag returns the highest semver tag among tags, or an empty
+// string if tags is empty.
+func highestSemverTag(tags []string) string {
+	highest := ""
+	for _, tag := range tags {
+		if highest == "" || semver.Compare(tag, highest) > 0 {
+			highest = tag
 		}
-		return nil
-	})
-	if err != nil {
-		return "", err
 	}
+	return highest
+}
 
-	if tagName == "" {
-		return "", plumbing.ErrObjectNotFound
+// splitSemver splits a semver tag into its majorMinorPatch (e.g. "v1.2.3")
+// and prerelease (e.g. "beta.1", may be empty) components.
+func splitSemver(tag string) (majorMinorPatch, prerelease string) {
+	if pre := semver.Prerelease(tag); pre != "" {
+		return strings.TrimSuffix(tag, pre), strings.TrimPrefix(pre, "-")
 	}
+	return tag, ""
+}
 
-	return tagName, nil
-}
\ No newline at end of file
+// incrementPatch bumps the patch version of a canonical "vX.Y.Z" version.
+func incrementPatch(version string) (string, error) {
+	var major, minor, patch int
+	if _, err := fmt.Sscanf(version, "v%d.%d.%d", &major, &minor, &patch); err != nil {
+		return "", fmt.Errorf("failed to parse version %s: %w", version, err)
+	}
+	return fmt.Sprintf("v%d.%d.%d", major, minor, patch+1), nil
+}
+
+// shortHash returns the 12-character prefix of a commit hash, as used in
+// pseudo-versions.
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
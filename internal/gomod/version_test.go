@@ -0,0 +1,229 @@
+package gomod
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CycloneDX/cyclonedx-gomod/internal/gomod/vcs"
+)
+
+// fakeVCS is a minimal, in-memory vcs.VCS implementation for exercising
+// pseudoVersionOf and versionFromTagOf without a real VCS checkout.
+type fakeVCS struct {
+	kind       string
+	head       string
+	commitTime time.Time
+	tagsAt     map[string][]string
+	ancestors  []string
+	goModAt    map[string]bool
+	remoteURL  string
+}
+
+func (f *fakeVCS) Kind() string { return f.kind }
+
+func (f *fakeVCS) Head() (string, time.Time, error) {
+	return f.head, f.commitTime, nil
+}
+
+func (f *fakeVCS) TagsAt(hash string) ([]string, error) {
+	return f.tagsAt[hash], nil
+}
+
+func (f *fakeVCS) AncestorTags(hash string) ([]string, error) {
+	return f.ancestors, nil
+}
+
+func (f *fakeVCS) ResolveTag(tag string) (string, error) {
+	for hash, tags := range f.tagsAt {
+		for _, t := range tags {
+			if t == tag {
+				return hash, nil
+			}
+		}
+	}
+	return "", ErrNoTag
+}
+
+func (f *fakeVCS) FileExistsAt(hash, relPath string) (bool, error) {
+	return f.goModAt[hash], nil
+}
+
+func (f *fakeVCS) Dirty() (bool, error) {
+	return false, nil
+}
+
+func (f *fakeVCS) RemoteURL() (string, error) {
+	return f.remoteURL, nil
+}
+
+var _ vcs.VCS = (*fakeVCS)(nil)
+
+func TestPseudoVersionOf(t *testing.T) {
+	commitTime := time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		repo    *fakeVCS
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "no tag",
+			repo: &fakeVCS{
+				kind:       "git",
+				head:       "abcdefabcdef0123456789",
+				commitTime: commitTime,
+				tagsAt:     map[string][]string{},
+			},
+			want: "v0.0.0-20210102150405-abcdefabcdef",
+		},
+		{
+			name: "release ancestor",
+			repo: &fakeVCS{
+				kind:       "git",
+				head:       "abcdefabcdef0123456789",
+				commitTime: commitTime,
+				tagsAt:     map[string][]string{},
+				ancestors:  []string{"v1.2.3"},
+				goModAt:    map[string]bool{"abcdefabcdef0123456789": true},
+			},
+			want: "v1.2.4-0.20210102150405-abcdefabcdef",
+		},
+		{
+			name: "prerelease ancestor",
+			repo: &fakeVCS{
+				kind:       "git",
+				head:       "abcdefabcdef0123456789",
+				commitTime: commitTime,
+				tagsAt:     map[string][]string{},
+				ancestors:  []string{"v1.2.3-beta.1"},
+				goModAt:    map[string]bool{"abcdefabcdef0123456789": true},
+			},
+			want: "v1.2.3-beta.1.0.20210102150405-abcdefabcdef",
+		},
+		{
+			name: "exact tag",
+			repo: &fakeVCS{
+				kind:       "git",
+				head:       "abcdefabcdef0123456789",
+				commitTime: commitTime,
+				tagsAt:     map[string][]string{"abcdefabcdef0123456789": {"v1.0.0"}},
+			},
+			want: "v1.0.0",
+		},
+		{
+			name: "incompatible ancestor, checked at HEAD not at the ancestor tag",
+			repo: &fakeVCS{
+				kind:       "git",
+				head:       "headhash0123456789abcd",
+				commitTime: commitTime,
+				tagsAt:     map[string][]string{"taghash0123456789abcde": {"v2.0.0"}},
+				ancestors:  []string{"v2.0.0"},
+				goModAt: map[string]bool{
+					// go.mod didn't exist at the tag, but was added by HEAD -
+					// the +incompatible decision must reflect HEAD's state.
+					"taghash0123456789abcde": false,
+					"headhash0123456789abcd": true,
+				},
+			},
+			want: "v2.0.1-0.20210102150405-headhash0123",
+		},
+		{
+			name: "incompatible",
+			repo: &fakeVCS{
+				kind:       "git",
+				head:       "headhash0123456789abcd",
+				commitTime: commitTime,
+				tagsAt:     map[string][]string{"taghash0123456789abcde": {"v2.0.0"}},
+				ancestors:  []string{"v2.0.0"},
+				goModAt:    map[string]bool{"headhash0123456789abcd": false},
+			},
+			want: "v2.0.1-0.20210102150405-headhash0123+incompatible",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, origin, err := pseudoVersionOf(tt.repo, "example.com/mod")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got version %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("pseudoVersionOf failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("pseudoVersionOf() = %q, want %q", got, tt.want)
+			}
+			if origin == nil {
+				t.Fatal("expected a non-nil Origin")
+			}
+		})
+	}
+}
+
+func TestVersionFromTagOf(t *testing.T) {
+	commitTime := time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		repo    *fakeVCS
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "head is tagged",
+			repo: &fakeVCS{
+				kind:       "git",
+				head:       "abcdefabcdef0123456789",
+				commitTime: commitTime,
+				tagsAt:     map[string][]string{"abcdefabcdef0123456789": {"v1.0.0"}},
+			},
+			want: "v1.0.0",
+		},
+		{
+			name: "head has no tag",
+			repo: &fakeVCS{
+				kind:       "git",
+				head:       "abcdefabcdef0123456789",
+				commitTime: commitTime,
+				tagsAt:     map[string][]string{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "incompatible major version tag",
+			repo: &fakeVCS{
+				kind:       "git",
+				head:       "headhash0123456789abcd",
+				commitTime: commitTime,
+				tagsAt:     map[string][]string{"headhash0123456789abcd": {"v2.0.0"}},
+				goModAt:    map[string]bool{"headhash0123456789abcd": false},
+			},
+			want: "v2.0.0+incompatible",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, origin, err := versionFromTagOf(tt.repo, "example.com/mod")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got version %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("versionFromTagOf failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("versionFromTagOf() = %q, want %q", got, tt.want)
+			}
+			if origin == nil {
+				t.Fatal("expected a non-nil Origin")
+			}
+		})
+	}
+}